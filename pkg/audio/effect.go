@@ -35,48 +35,61 @@ type Effect struct {
 	Type       EffectType
 	Mix        float64 // Dry/wet mix (0.0 = dry, 1.0 = wet)
 	Parameters map[string]float64
-	
+
 	// Delay line for time-based effects
 	delayLine  []float32
 	delayIndex int
-	
+
 	// For chorus/flanger
-	lfoPhase   float64
-	
+	lfoPhase float64
+
 	// For bit crusher
 	sampleHold float32
 	holdCount  int
+
+	// For reverb
+	reverb *reverbState
+
+	sampleRate int
 }
 
-func NewEffect(effectType EffectType) *Effect {
+func NewEffect(effectType EffectType, sampleRate int) *Effect {
 	e := &Effect{
 		Type:       effectType,
 		Mix:        0.5,
 		Parameters: make(map[string]float64),
+		sampleRate: sampleRate,
 	}
-	
+
 	// Initialize based on type
 	switch effectType {
 	case EffectDelay:
-		e.delayLine = make([]float32, SampleRate) // 1 second max delay
-		e.Parameters["time"] = 0.25               // 250ms default
+		e.delayLine = make([]float32, sampleRate) // 1 second max delay
+		e.Parameters["time"] = 0.25                // 250ms default
 		e.Parameters["feedback"] = 0.3
-		
+
 	case EffectDistortion:
 		e.Parameters["drive"] = 5.0
 		e.Parameters["level"] = 0.7
-		
+
 	case EffectChorus:
-		e.delayLine = make([]float32, SampleRate/10) // 100ms max delay
+		e.delayLine = make([]float32, sampleRate/10) // 100ms max delay
 		e.Parameters["rate"] = 0.5                    // LFO rate in Hz
 		e.Parameters["depth"] = 0.3                   // Modulation depth
 		e.Parameters["delay"] = 0.02                  // Base delay time
-		
+
 	case EffectBitCrusher:
-		e.Parameters["bits"] = 8.0      // Bit depth
+		e.Parameters["bits"] = 8.0       // Bit depth
 		e.Parameters["sampleRate"] = 0.5 // Sample rate reduction factor
+
+	case EffectReverb:
+		e.Parameters["roomSize"] = 1.0
+		e.Parameters["damping"] = 0.3
+		e.Parameters["diffusion"] = 0.6
+		e.Parameters["width"] = 0.5
+		e.reverb = newReverbState(sampleRate)
 	}
-	
+
 	return e
 }
 
@@ -90,13 +103,15 @@ func (e *Effect) Process(buffer []float32, params *ParameterManager) {
 		e.processChorus(buffer)
 	case EffectBitCrusher:
 		e.processBitCrusher(buffer)
+	case EffectReverb:
+		e.processReverb(buffer)
 	}
 }
 
 func (e *Effect) processDelay(buffer []float32) {
 	delayTime := e.Parameters["time"]
 	feedback := e.Parameters["feedback"]
-	delaySamples := int(delayTime * float64(SampleRate))
+	delaySamples := int(delayTime * float64(e.sampleRate))
 	
 	// Ensure delay samples doesn't exceed buffer size
 	if delaySamples >= len(e.delayLine) {
@@ -147,7 +162,7 @@ func (e *Effect) processChorus(buffer []float32) {
 	depth := e.Parameters["depth"]
 	baseDelay := e.Parameters["delay"]
 	
-	lfoIncrement := 2.0 * math.Pi * rate / float64(SampleRate)
+	lfoIncrement := 2.0 * math.Pi * rate / float64(e.sampleRate)
 	
 	for i := range buffer {
 		// Calculate LFO value for modulation
@@ -159,21 +174,22 @@ func (e *Effect) processChorus(buffer []float32) {
 		
 		// Calculate modulated delay time
 		delayTime := baseDelay + baseDelay*lfo
-		delaySamples := delayTime * float64(SampleRate)
+		delaySamples := delayTime * float64(e.sampleRate)
 		
-		// Linear interpolation for fractional delay
+		// Cubic interpolation for fractional delay (smoother than linear
+		// under LFO modulation, and cheap enough to run per-sample)
 		delaySamplesInt := int(delaySamples)
 		fraction := delaySamples - float64(delaySamplesInt)
-		
-		if delaySamplesInt < len(e.delayLine)-1 {
-			readIndex1 := (e.delayIndex - delaySamplesInt + len(e.delayLine)) % len(e.delayLine)
-			readIndex2 := (readIndex1 - 1 + len(e.delayLine)) % len(e.delayLine)
-			
-			// Linear interpolation between two samples
-			sample1 := e.delayLine[readIndex1]
-			sample2 := e.delayLine[readIndex2]
-			delayed := sample1*(1-float32(fraction)) + sample2*float32(fraction)
-			
+
+		if delaySamplesInt < len(e.delayLine)-2 {
+			n := len(e.delayLine)
+			idx0 := (e.delayIndex - delaySamplesInt + n) % n
+			idxNewer := (idx0 + 1) % n
+			idx1 := (idx0 - 1 + n) % n
+			idxOlder := (idx1 - 1 + n) % n
+
+			delayed := cubicInterp(e.delayLine[idxNewer], e.delayLine[idx0], e.delayLine[idx1], e.delayLine[idxOlder], float32(fraction))
+
 			// Write current sample to delay line
 			e.delayLine[e.delayIndex] = buffer[i]
 			
@@ -185,6 +201,18 @@ func (e *Effect) processChorus(buffer []float32) {
 	}
 }
 
+// cubicInterp performs 4-point, third-order Hermite (Catmull-Rom)
+// interpolation between y1 and y2, using y0 and y3 as the neighbors just
+// outside that span. frac is in [0, 1), where 0 returns y1 and 1 would
+// return y2.
+func cubicInterp(y0, y1, y2, y3, frac float32) float32 {
+	c0 := y1
+	c1 := 0.5 * (y2 - y0)
+	c2 := y0 - 2.5*y1 + 2*y2 - 0.5*y3
+	c3 := 0.5*(y3-y0) + 1.5*(y1-y2)
+	return ((c3*frac+c2)*frac+c1)*frac + c0
+}
+
 func (e *Effect) processBitCrusher(buffer []float32) {
 	bits := e.Parameters["bits"]
 	sampleRateReduction := e.Parameters["sampleRate"]
@@ -230,4 +258,22 @@ func (e *Effect) Reset() {
 	e.lfoPhase = 0
 	e.sampleHold = 0
 	e.holdCount = 0
+
+	if e.reverb != nil {
+		for _, buf := range e.reverb.diffuserBufs {
+			for i := range buf {
+				buf[i] = 0
+			}
+		}
+		for _, line := range e.reverb.fdnLines {
+			for i := range line {
+				line[i] = 0
+			}
+		}
+		e.reverb.fdnIdx = [4]int{}
+		e.reverb.fdnLP = [4]float32{}
+		for i := range e.reverb.diffuserIdx {
+			e.reverb.diffuserIdx[i] = 0
+		}
+	}
 }
\ No newline at end of file
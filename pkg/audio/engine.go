@@ -4,14 +4,25 @@ import (
 	"fmt"
 	"log"
 	"sync/atomic"
+	"time"
 
 	"github.com/gordonklaus/portaudio"
 )
 
 const (
-	SampleRate = 44100
-	FrameSize  = 512
-	Channels   = 2 // Stereo output
+	// DefaultSampleRate is used when an engine isn't built with an explicit
+	// rate via NewAudioEngineWithSampleRate.
+	DefaultSampleRate = 44100
+	FrameSize         = 512
+	Channels          = 2 // Stereo output
+)
+
+// resamplerTaps and resamplerBeta configure the polyphase FIR filter the
+// engine builds when its internal sample rate differs from the output
+// device's native rate.
+const (
+	resamplerTaps = 32
+	resamplerBeta = 8.0
 )
 
 // AudioEngine manages the entire audio processing pipeline
@@ -19,39 +30,71 @@ type AudioEngine struct {
 	stream    *portaudio.Stream
 	isRunning int32 // atomic bool
 
-	// Audio processing chain
-	oscillators []*Oscillator
-	filters     []*Filter
-	effects     []*Effect
+	// sampleRate is the rate the DSP graph (oscillators, filters, effects)
+	// runs at. It may differ from the audio device's native rate, in which
+	// case Start installs a resampler between the two.
+	sampleRate int
+
+	// Audio processing chain. voices has its own NoteOn/NoteOff ring
+	// buffer (mirroring params' below) so MIDI dispatch never touches
+	// Voice state directly; see VoiceManager.Process in voice.go.
+	voices  *VoiceManager
+	filters []*Filter
+	effects []*Effect
 
 	// Real-time parameter control
-	params *ParameterManager // Changed from 'param' to 'params' for consistency
+	params    *ParameterManager // Changed from 'param' to 'params' for consistency
+	modMatrix *ModMatrix
+
+	// Set by Start when the output device's native rate differs from
+	// sampleRate, to convert the internal mix to the device's rate.
+	resampler        *Resampler
+	resamplerPending []float32
+	deviceSampleRate int
 }
 
-// NewAudioEngine creates a new audio engine instance
+// NewAudioEngine creates a new audio engine instance running at
+// DefaultSampleRate.
 func NewAudioEngine() *AudioEngine {
+	return NewAudioEngineWithSampleRate(DefaultSampleRate)
+}
+
+// NewAudioEngineWithSampleRate creates a new audio engine whose DSP graph
+// runs at sampleRate Hz. If the output device Start() opens turns out to
+// run at a different native rate, the engine resamples its output rather
+// than pitch-shifting.
+func NewAudioEngineWithSampleRate(sampleRate int) *AudioEngine {
 	engine := &AudioEngine{
-		oscillators: make([]*Oscillator, 0, 8), // Support up to 8 oscillators
-		filters:     make([]*Filter, 0, 4),      // Support up to 4 filters
-		effects:     make([]*Effect, 0, 8),      // Support up to 8 effects
-		params:      NewParameterManager(),
+		sampleRate: sampleRate,
+		voices:     NewVoiceManager(DefaultVoiceCount, sampleRate),
+		filters:    make([]*Filter, 0, 4), // Support up to 4 filters
+		effects:    make([]*Effect, 0, 8), // Support up to 8 effects
+		params:     NewParameterManager(),
 	}
-
-	// Add default oscillator
-	osc := NewOscillator(OscSine, 440.0, 0.3)
-	engine.AddOscillator(osc)
+	engine.modMatrix = NewModMatrix(engine.params)
 
 	// Add default low-pass filter
-	filter := NewLowPassFilter(1000.0, 0.7) // 1kHz cutoff, 0.7 resonance
+	filter := NewLowPassFilter(1000.0, 0.7, sampleRate) // 1kHz cutoff, 0.7 resonance
 	engine.AddFilter(filter)
 
 	return engine
 }
 
-// AddOscillator adds an oscillator to the processing chain
-func (ae *AudioEngine) AddOscillator(osc *Oscillator) {
-	ae.oscillators = append(ae.oscillators, osc)
-	log.Printf("Added %s oscillator at %.1f Hz", osc.Type.String(), osc.Frequency)
+// SampleRate returns the rate the DSP graph runs at.
+func (ae *AudioEngine) SampleRate() int {
+	return ae.sampleRate
+}
+
+// NoteOn triggers a voice for the given MIDI note number (0-127) at the
+// given velocity (0.0-1.0).
+func (ae *AudioEngine) NoteOn(note int, velocity float64) {
+	ae.voices.NoteOn(note, velocity)
+}
+
+// NoteOff releases the voice(s) currently playing the given MIDI note
+// number into their envelope's Release stage.
+func (ae *AudioEngine) NoteOff(note int) {
+	ae.voices.NoteOff(note)
 }
 
 // AddFilter adds a filter to the processing chain
@@ -79,45 +122,129 @@ func (ae *AudioEngine) processAudio(out [][]float32) {
 	}
 
 	frameCount := len(out[0])
-
-	// Generate audio from oscillators
 	mixBuffer := make([]float32, frameCount)
-	for _, osc := range ae.oscillators {
-		tempBuffer := make([]float32, frameCount)
-		osc.Generate(tempBuffer, ae.params)
-		
-		// Mix oscillator output
-		for i := range mixBuffer {
-			mixBuffer[i] += tempBuffer[i]
-		}
+
+	if ae.resampler != nil {
+		ae.renderResampled(mixBuffer)
+	} else {
+		ae.processBlock(mixBuffer)
 	}
 
+	// Copy to output channels (stereo)
+	for ch := 0; ch < len(out); ch++ {
+		copy(out[ch], mixBuffer)
+	}
+}
+
+// processBlock runs the oscillator->filter->effect DSP graph over mix in
+// place, at the engine's own sampleRate. It has no dependency on
+// PortAudio, so it also backs the offline rendering path (RenderFrames,
+// RenderToWAV).
+func (ae *AudioEngine) processBlock(mix []float32) {
+	// Drain queued Set() updates into the lock-free hot-path slice before
+	// anything below reads a parameter.
+	ae.params.DrainUpdates()
+
+	// Advance the modulation matrix first so LFOs/envelopes routed onto
+	// filter or effect parameters are up to date before this block uses them.
+	if ae.modMatrix != nil {
+		ae.modMatrix.Tick(float64(len(mix)) / float64(ae.sampleRate))
+	}
+
+	// Sum all active voices into the mix buffer
+	ae.voices.Process(mix)
+
 	// Apply filters
 	for _, filter := range ae.filters {
-		filter.Process(mixBuffer, ae.params)
+		filter.Process(mix, ae.params)
 	}
 
 	// Apply effects
 	for _, effect := range ae.effects {
-		effect.Process(mixBuffer, ae.params)
+		effect.Process(mix, ae.params)
 	}
+}
 
-	// Copy to output channels (stereo)
-	for ch := 0; ch < len(out); ch++ {
+// renderResampled fills mix (at the device's native rate) by running
+// processBlock at the engine's own sampleRate in FrameSize-sized chunks
+// and feeding them through ae.resampler, which carries its filter state
+// across calls so the stream stays continuous.
+func (ae *AudioEngine) renderResampled(mix []float32) {
+	produced := 0
+	for produced < len(mix) {
+		if len(ae.resamplerPending) == 0 {
+			chunk := make([]float32, FrameSize)
+			ae.processBlock(chunk)
+			ae.resamplerPending = chunk
+		}
+
+		consumed, n := ae.resampler.Process(ae.resamplerPending, mix[produced:])
+		ae.resamplerPending = ae.resamplerPending[consumed:]
+		produced += n
+
+		if n == 0 && len(ae.resamplerPending) == 0 {
+			break // shouldn't happen outside pathological taps/rates, but avoid spinning
+		}
+	}
+}
+
+// RenderFrames renders len(out[0]) frames through the DSP graph without
+// PortAudio, writing the same mixed signal to every channel in out. This
+// is the non-realtime counterpart to processAudio, useful for tests and
+// headless rendering. Frames are rendered at the engine's own sampleRate,
+// independent of any output device.
+func (ae *AudioEngine) RenderFrames(out [][]float32) {
+	if len(out) == 0 || len(out[0]) == 0 {
+		return
+	}
+
+	mixBuffer := make([]float32, len(out[0]))
+	ae.processBlock(mixBuffer)
+
+	for ch := range out {
 		copy(out[ch], mixBuffer)
 	}
 }
 
+// RenderToWAV renders duration worth of audio through the DSP graph and
+// writes it to path as a stereo 16-bit PCM WAV file at the engine's own
+// sampleRate.
+func (ae *AudioEngine) RenderToWAV(path string, duration time.Duration) error {
+	frameCount := int(duration.Seconds() * float64(ae.sampleRate))
+	if frameCount <= 0 {
+		return fmt.Errorf("render duration must be positive")
+	}
+
+	out := make([][]float32, Channels)
+	for ch := range out {
+		out[ch] = make([]float32, frameCount)
+	}
+	ae.RenderFrames(out)
+
+	return writeWAV(path, out, ae.sampleRate)
+}
+
 // Start begins audio processing
 func (ae *AudioEngine) Start() error {
 	if err := portaudio.Initialize(); err != nil {
 		return fmt.Errorf("failed to initialize PortAudio: %v", err)
 	}
 
+	deviceRate := ae.sampleRate
+	if dev, err := portaudio.DefaultOutputDevice(); err == nil && int(dev.DefaultSampleRate) > 0 {
+		deviceRate = int(dev.DefaultSampleRate)
+	}
+
+	if deviceRate != ae.sampleRate {
+		ae.resampler = NewResampler(ae.sampleRate, deviceRate, resamplerTaps, resamplerBeta)
+		ae.deviceSampleRate = deviceRate
+		log.Printf("Resampling engine output %d Hz -> device native %d Hz", ae.sampleRate, deviceRate)
+	}
+
 	stream, err := portaudio.OpenDefaultStream(
-		0,                   // input channels
-		Channels,            // output channels
-		SampleRate,          // sample rate as float64 (PortAudio expects float64)
+		0,          // input channels
+		Channels,   // output channels
+		float64(deviceRate),
 		FrameSize,
 		ae.processAudio,
 	)
@@ -133,7 +260,7 @@ func (ae *AudioEngine) Start() error {
 		return fmt.Errorf("failed to start audio stream: %v", err)
 	}
 
-	log.Printf("ðŸ”¥ Hephaestus Forge started (SR: %d Hz, Buffer: %d frames)", SampleRate, FrameSize)
+	log.Printf("ðŸ”¥ Hephaestus Forge started (SR: %d Hz, Buffer: %d frames)", ae.sampleRate, FrameSize)
 	return nil
 }
 
@@ -158,4 +285,15 @@ func (ae *AudioEngine) IsRunning() bool {
 // GetParameterManager returns the parameter manager for real-time control
 func (ae *AudioEngine) GetParameterManager() *ParameterManager {
 	return ae.params
-}
\ No newline at end of file
+}
+
+// GetVoiceManager returns the voice manager for polyphonic note control
+func (ae *AudioEngine) GetVoiceManager() *VoiceManager {
+	return ae.voices
+}
+
+// GetModMatrix returns the modulation matrix routing LFOs, envelopes and
+// other ModSources onto engine parameters.
+func (ae *AudioEngine) GetModMatrix() *ModMatrix {
+	return ae.modMatrix
+}
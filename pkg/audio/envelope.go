@@ -0,0 +1,107 @@
+package audio
+
+// EnvelopeStage identifies which segment of an ADSR envelope is currently
+// being generated.
+type EnvelopeStage int
+
+const (
+	StageIdle EnvelopeStage = iota
+	StageAttack
+	StageDecay
+	StageSustain
+	StageRelease
+)
+
+// Envelope is a classic ADSR (Attack/Decay/Sustain/Release) envelope
+// generator. Attack, Decay and Release are expressed in seconds; Sustain
+// is a level in the range 0.0-1.0. Envelope implements ModSource, so the
+// same envelope driving a voice's amplitude can also be routed through a
+// ModMatrix to modulate any other parameter.
+type Envelope struct {
+	Attack  float64
+	Decay   float64
+	Sustain float64
+	Release float64
+
+	stage             EnvelopeStage
+	level             float64
+	releaseStartLevel float64
+}
+
+// NewEnvelope creates an idle envelope with the given ADSR settings.
+func NewEnvelope(attack, decay, sustain, release float64) *Envelope {
+	return &Envelope{
+		Attack:  attack,
+		Decay:   decay,
+		Sustain: sustain,
+		Release: release,
+		stage:   StageIdle,
+	}
+}
+
+// NoteOn (re)triggers the envelope from whatever level it is currently at
+// and moves it into the Attack stage.
+func (e *Envelope) NoteOn() {
+	e.stage = StageAttack
+}
+
+// NoteOff moves the envelope into Release, decaying from its current level
+// to zero over Release seconds.
+func (e *Envelope) NoteOff() {
+	if e.Release <= 0 {
+		e.level = 0
+		e.stage = StageIdle
+		return
+	}
+	e.releaseStartLevel = e.level
+	e.stage = StageRelease
+}
+
+// Tick advances the envelope by dt seconds and returns its current level.
+func (e *Envelope) Tick(dt float64) float64 {
+	switch e.stage {
+	case StageAttack:
+		if e.Attack <= 0 {
+			e.level = 1.0
+		} else {
+			e.level += dt / e.Attack
+		}
+		if e.level >= 1.0 {
+			e.level = 1.0
+			e.stage = StageDecay
+		}
+
+	case StageDecay:
+		if e.Decay <= 0 {
+			e.level = e.Sustain
+			e.stage = StageSustain
+		} else {
+			e.level -= dt * (1.0 - e.Sustain) / e.Decay
+			if e.level <= e.Sustain {
+				e.level = e.Sustain
+				e.stage = StageSustain
+			}
+		}
+
+	case StageSustain:
+		e.level = e.Sustain
+
+	case StageRelease:
+		e.level -= dt * e.releaseStartLevel / e.Release
+		if e.level <= 0 {
+			e.level = 0
+			e.stage = StageIdle
+		}
+
+	case StageIdle:
+		e.level = 0
+	}
+
+	return e.level
+}
+
+// IsIdle reports whether the envelope has finished its release and is
+// producing silence.
+func (e *Envelope) IsIdle() bool {
+	return e.stage == StageIdle
+}
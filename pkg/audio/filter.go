@@ -32,29 +32,40 @@ type Filter struct {
 	Type      FilterType
 	Frequency float64
 	Resonance float64
-	
+
 	// State variables for biquad filter
 	a0, a1, a2 float64
 	b0, b1, b2 float64
 	x1, x2     float64
 	y1, y2     float64
+
+	sampleRate int
+
+	// freqParamID and resParamID are lazily registered on the first
+	// Process call so later calls read parameter updates via GetByID
+	// instead of taking the ParameterManager's lock.
+	paramsBound bool
+	freqParamID ParamID
+	resParamID  ParamID
 }
 
-func NewLowPassFilter(freq, resonance float64) *Filter {
+func NewLowPassFilter(freq, resonance float64, sampleRate int) *Filter {
 	f := &Filter{
-		Type:      FilterLowPass,
-		Frequency: freq,
-		Resonance: resonance,
+		Type:       FilterLowPass,
+		Frequency:  freq,
+		Resonance:  resonance,
+		sampleRate: sampleRate,
 	}
 	f.updateCoefficients()
 	return f
 }
 
-func NewHighPassFilter(freq, resonance float64) *Filter {
+func NewHighPassFilter(freq, resonance float64, sampleRate int) *Filter {
 	f := &Filter{
-		Type:      FilterHighPass,
-		Frequency: freq,
-		Resonance: resonance,
+		Type:       FilterHighPass,
+		Frequency:  freq,
+		Resonance:  resonance,
+		sampleRate: sampleRate,
 	}
 	f.updateCoefficients()
 	return f
@@ -62,7 +73,7 @@ func NewHighPassFilter(freq, resonance float64) *Filter {
 
 func (f *Filter) updateCoefficients() {
 	// Butterworth filter coefficients
-	omega := 2.0 * math.Pi * f.Frequency / float64(SampleRate)
+	omega := 2.0 * math.Pi * f.Frequency / float64(f.sampleRate)
 	sin := math.Sin(omega)
 	cos := math.Cos(omega)
 	
@@ -120,12 +131,18 @@ func (f *Filter) updateCoefficients() {
 }
 
 func (f *Filter) Process(buffer []float32, params *ParameterManager) {
-	// Check for filter parameter updates
-	if newFreq, exists := params.Get("filter_frequency"); exists {
+	if !f.paramsBound {
+		f.freqParamID = params.RegisterParam("filter_frequency")
+		f.resParamID = params.RegisterParam("filter_resonance")
+		f.paramsBound = true
+	}
+
+	// Check for filter parameter updates via the lock-free hot path
+	if newFreq, exists := params.GetByID(f.freqParamID); exists {
 		f.Frequency = newFreq
 		f.updateCoefficients()
 	}
-	if newRes, exists := params.Get("filter_resonance"); exists {
+	if newRes, exists := params.GetByID(f.resParamID); exists {
 		f.Resonance = newRes
 		f.updateCoefficients()
 	}
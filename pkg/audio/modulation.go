@@ -0,0 +1,187 @@
+package audio
+
+import "math"
+
+// ModSource is anything that can drive a modulation route: an LFO, an
+// envelope, a sample & hold generator, or in principle an incoming MIDI
+// CC value. Tick advances the source by dt seconds and returns its
+// current value, nominally in -1.0..1.0.
+type ModSource interface {
+	Tick(dt float64) float64
+}
+
+// Curve reshapes a mod source's output before it's scaled by a Route's
+// Amount, e.g. for exponential filter sweeps or asymmetric vibrato.
+type Curve func(value float64) float64
+
+// LinearCurve passes a source's value through unchanged. It's the default
+// when a Route doesn't specify one.
+func LinearCurve(value float64) float64 {
+	return value
+}
+
+// LFO is a free-running low-frequency oscillator used as a modulation
+// source. It reuses the same waveform shapes as Oscillator.
+type LFO struct {
+	Shape OscillatorType
+	Rate  float64 // Hz
+	Phase float64 // 0..2pi
+}
+
+// NewLFO creates an LFO of the given shape and rate, starting at phase 0.
+func NewLFO(shape OscillatorType, rate float64) *LFO {
+	return &LFO{Shape: shape, Rate: rate}
+}
+
+// Tick advances the LFO by dt seconds and returns its current value in
+// -1.0..1.0.
+func (l *LFO) Tick(dt float64) float64 {
+	var sample float64
+	switch l.Shape {
+	case OscSine:
+		sample = math.Sin(l.Phase)
+	case OscSaw:
+		sample = 2.0*(l.Phase/(2.0*math.Pi)) - 1.0
+	case OscSquare:
+		if l.Phase < math.Pi {
+			sample = 1.0
+		} else {
+			sample = -1.0
+		}
+	case OscTriangle:
+		if l.Phase < math.Pi {
+			sample = -1.0 + (2.0 * l.Phase / math.Pi)
+		} else {
+			sample = 3.0 - (2.0 * l.Phase / math.Pi)
+		}
+	case OscNoise:
+		sample = randFloat64()*2.0 - 1.0
+	}
+
+	l.Phase += 2.0 * math.Pi * l.Rate * dt
+	if l.Phase >= 2.0*math.Pi {
+		l.Phase -= 2.0 * math.Pi
+	}
+	return sample
+}
+
+// SampleHold latches a new pseudo-random value at Rate Hz and holds it
+// until the next latch, for stepped "random" modulation.
+type SampleHold struct {
+	Rate float64 // Hz
+
+	seed  uint64
+	phase float64
+	value float64
+}
+
+// NewSampleHold creates a SampleHold generator latching at rate Hz, seeded
+// by seed (0 is replaced with a fixed non-zero default).
+func NewSampleHold(rate float64, seed uint64) *SampleHold {
+	if seed == 0 {
+		seed = 1
+	}
+	return &SampleHold{Rate: rate, seed: seed}
+}
+
+// Tick advances the generator by dt seconds and returns its currently
+// held value in -1.0..1.0.
+func (s *SampleHold) Tick(dt float64) float64 {
+	s.phase += s.Rate * dt
+	if s.phase >= 1.0 {
+		s.phase -= 1.0
+		s.seed = s.seed*6364136223846793005 + 1442695040888963407
+		s.value = float64(s.seed>>32)/float64(1<<32)*2.0 - 1.0
+	}
+	return s.value
+}
+
+// Route connects one modulation source to one destination parameter key:
+// the source's (curved) output, scaled by Amount, is added to the
+// destination's base value.
+type Route struct {
+	Source  ModSource
+	DestKey string
+	Amount  float64
+	Curve   Curve // defaults to LinearCurve if nil
+}
+
+// ModMatrix ticks a set of modulation sources once per block and sums
+// their routed, scaled contributions onto each destination parameter, so
+// filter sweeps, chorus wobble, pitch vibrato and tremolo become a couple
+// of routes instead of hard-coded parameter pokes.
+type ModMatrix struct {
+	params *ParameterManager
+	routes []Route
+	bases  map[string]float64
+
+	// destIDs holds each routed destination key's ParamID, registered once
+	// (in AddRoute, at setup time) so Tick can write through
+	// SetModulatedByID without ever taking ParameterManager's lock.
+	destIDs map[string]ParamID
+
+	// ticked and sums are Tick's scratch maps, reused block to block (and
+	// cleared in place) so modulation processing on the audio thread
+	// doesn't allocate once any route exists.
+	ticked map[ModSource]float64
+	sums   map[string]float64
+}
+
+// NewModMatrix creates an empty modulation matrix writing through params.
+func NewModMatrix(params *ParameterManager) *ModMatrix {
+	return &ModMatrix{
+		params:  params,
+		bases:   make(map[string]float64),
+		destIDs: make(map[string]ParamID),
+		ticked:  make(map[ModSource]float64),
+		sums:    make(map[string]float64),
+	}
+}
+
+// SetBase sets the unmodulated value for a destination key. Modulation is
+// layered on top of this every Tick via ParameterManager.SetModulatedByID.
+func (m *ModMatrix) SetBase(key string, value float64) {
+	m.bases[key] = value
+}
+
+// AddRoute registers a modulation route. A nil Curve is replaced with
+// LinearCurve. This registers route.DestKey with the ParameterManager if
+// it hasn't been already, so call AddRoute during setup, not from the
+// audio thread.
+func (m *ModMatrix) AddRoute(route Route) {
+	if route.Curve == nil {
+		route.Curve = LinearCurve
+	}
+	m.routes = append(m.routes, route)
+	if _, exists := m.destIDs[route.DestKey]; !exists {
+		m.destIDs[route.DestKey] = m.params.RegisterParam(route.DestKey)
+	}
+}
+
+// Tick advances every distinct source exactly once by dt seconds, then
+// writes base + sum(amount*curve(source)) to each routed destination.
+func (m *ModMatrix) Tick(dt float64) {
+	if len(m.routes) == 0 {
+		return
+	}
+
+	for k := range m.ticked {
+		delete(m.ticked, k)
+	}
+	for k := range m.sums {
+		delete(m.sums, k)
+	}
+
+	for _, r := range m.routes {
+		value, ok := m.ticked[r.Source]
+		if !ok {
+			value = r.Source.Tick(dt)
+			m.ticked[r.Source] = value
+		}
+		m.sums[r.DestKey] += r.Amount * r.Curve(value)
+	}
+
+	for key, sum := range m.sums {
+		m.params.SetModulatedByID(m.destIDs[key], m.bases[key]+sum)
+	}
+}
@@ -0,0 +1,20 @@
+package audio
+
+import "testing"
+
+// TestModMatrixTickDoesNotAllocate is a regression test for Tick allocating
+// two new maps every call: once a route exists, repeated calls must reuse
+// the same scratch maps rather than allocating on the audio thread.
+func TestModMatrixTickDoesNotAllocate(t *testing.T) {
+	params := NewParameterManager()
+	m := NewModMatrix(params)
+	m.SetBase("filter_frequency", 1000.0)
+	m.AddRoute(Route{Source: NewLFO(OscSine, 2.0), DestKey: "filter_frequency", Amount: 200.0})
+
+	allocs := testing.AllocsPerRun(100, func() {
+		m.Tick(1.0 / 44100.0)
+	})
+	if allocs != 0 {
+		t.Fatalf("Tick allocated %.0f times per call, want 0", allocs)
+	}
+}
@@ -37,25 +37,35 @@ type Oscillator struct {
 	Amplitude float64
 	Phase     float64
 	phaseInc  float64
+
+	sampleRate int
 }
 
-func NewOscillator(oscType OscillatorType, freq, amp float64) *Oscillator {
-	return &Oscillator{
-		Type:      oscType,
-		Frequency: freq,
-		Amplitude: amp,
-		Phase:     0,
-		phaseInc:  2.0 * math.Pi * freq / float64(SampleRate),
+// NewOscillator creates an oscillator running at sampleRate Hz. Pass
+// DefaultSampleRate unless the caller (engine) was built with a custom rate.
+func NewOscillator(oscType OscillatorType, freq, amp float64, sampleRate int) *Oscillator {
+	o := &Oscillator{
+		Type:       oscType,
+		Frequency:  freq,
+		Amplitude:  amp,
+		Phase:      0,
+		sampleRate: sampleRate,
 	}
+	o.SetFrequency(freq)
+	return o
 }
 
-func (o *Oscillator) Generate(buffer []float32, params *ParameterManager) {
-	// Check for frequency parameter updates
-	if newFreq, exists := params.Get("osc1_frequency"); exists {
-		o.Frequency = newFreq
-		o.phaseInc = 2.0 * math.Pi * newFreq / float64(SampleRate)
-	}
+// SetFrequency updates the oscillator's frequency and recomputes its phase
+// increment.
+func (o *Oscillator) SetFrequency(freq float64) {
+	o.Frequency = freq
+	o.phaseInc = 2.0 * math.Pi * freq / float64(o.sampleRate)
+}
 
+// generateSamples renders raw waveform samples for one block, advancing
+// the oscillator's phase by its current frequency. Callers (e.g. Voice)
+// own the oscillator's frequency directly via SetFrequency.
+func (o *Oscillator) generateSamples(buffer []float32) {
 	for i := range buffer {
 		var sample float64
 
@@ -10,13 +10,23 @@ import (
 type ParameterManager struct {
 	mu     sync.RWMutex
 	params map[string]float64
-	
+
 	// Atomic parameters for lock-free access in hot paths
 	atomicParams map[string]*atomic.Value
-	
+
 	// Parameter smoothing for avoiding clicks
 	smoothing    map[string]*ParameterSmoother
 	smoothingMu  sync.RWMutex
+
+	// ring carries Set() updates from any control-thread goroutine to the
+	// audio thread without the audio thread ever taking a lock to read
+	// them; see RegisterParam/GetByID/DrainUpdates.
+	ring *paramRing
+
+	idsMu sync.RWMutex
+	ids   map[string]ParamID
+	dense []float64
+	has   []bool
 }
 
 // ParameterSmoother provides smooth parameter transitions
@@ -32,9 +42,70 @@ func NewParameterManager() *ParameterManager {
 		params:       make(map[string]float64),
 		atomicParams: make(map[string]*atomic.Value),
 		smoothing:    make(map[string]*ParameterSmoother),
+		ring:         &paramRing{},
+		ids:          make(map[string]ParamID),
 	}
 }
 
+// ParamID identifies a parameter registered via RegisterParam for
+// zero-lock, zero-allocation hot-path access through GetByID.
+type ParamID int32
+
+// paramUpdate is a single parameter write queued by Set for the audio
+// thread to apply to the dense, audio-thread-private parameter slice.
+type paramUpdate struct {
+	id    ParamID
+	value float64
+}
+
+// paramRingSize is the ring buffer's capacity in updates. It must be a
+// power of two. Control-thread parameter changes (MIDI CCs, OSC messages)
+// arrive far slower than audio blocks are processed, so this comfortably
+// absorbs a burst between two drains.
+const paramRingSize = 256
+
+// paramRing is a lock-free-on-the-consumer-side ring buffer of
+// paramUpdates: any number of control-thread goroutines may call push
+// concurrently (serialized by a small mutex, since that side isn't
+// real-time-critical), while drain — called once per block from the audio
+// thread — never takes a lock. head is only ever written by push, tail
+// only ever written by drain; both are read across goroutines via atomics
+// so neither side blocks or races the other.
+type paramRing struct {
+	buf        [paramRingSize]paramUpdate
+	head       uint32 // next slot to write
+	tail       uint32 // next slot to read
+	producerMu sync.Mutex
+}
+
+func (r *paramRing) push(u paramUpdate) {
+	r.producerMu.Lock()
+	defer r.producerMu.Unlock()
+
+	head := atomic.LoadUint32(&r.head)
+	tail := atomic.LoadUint32(&r.tail)
+	if head-tail >= paramRingSize {
+		// Audio thread isn't draining (e.g. engine stopped); drop the
+		// update rather than block a control-thread goroutine.
+		return
+	}
+	r.buf[head%paramRingSize] = u
+	atomic.StoreUint32(&r.head, head+1)
+}
+
+// drain applies every update pushed since the last drain, in order, via
+// apply. It must only be called from the audio thread.
+func (r *paramRing) drain(apply func(id ParamID, value float64)) {
+	head := atomic.LoadUint32(&r.head)
+	tail := atomic.LoadUint32(&r.tail)
+	for tail != head {
+		u := r.buf[tail%paramRingSize]
+		apply(u.id, u.value)
+		tail++
+	}
+	atomic.StoreUint32(&r.tail, tail)
+}
+
 // Set updates a parameter value
 func (pm *ParameterManager) Set(key string, value float64) {
 	pm.mu.Lock()
@@ -54,6 +125,15 @@ func (pm *ParameterManager) Set(key string, value float64) {
 		smoother.active = true
 	}
 	pm.smoothingMu.RUnlock()
+
+	// Queue the update for the audio thread's lock-free GetByID path, if
+	// this key has been registered with RegisterParam.
+	pm.idsMu.RLock()
+	id, registered := pm.ids[key]
+	pm.idsMu.RUnlock()
+	if registered {
+		pm.ring.push(paramUpdate{id: id, value: value})
+	}
 }
 
 // Get retrieves a parameter value
@@ -94,6 +174,83 @@ func (pm *ParameterManager) GetSmoothed(key string, defaultValue float64) float6
 	return pm.GetWithDefault(key, defaultValue)
 }
 
+// SmoothingActive reports whether key has smoothing enabled and is
+// currently gliding toward its target. It does not advance the smoother,
+// so it's safe to poll from outside the audio thread (e.g. a control
+// surface watching for a glide to finish).
+func (pm *ParameterManager) SmoothingActive(key string) bool {
+	pm.smoothingMu.RLock()
+	defer pm.smoothingMu.RUnlock()
+
+	smoother, exists := pm.smoothing[key]
+	return exists && smoother.active
+}
+
+// RegisterParam assigns name a stable ParamID for lock-free hot-path reads
+// via GetByID, seeded with name's current value (if any). Registering the
+// same name twice returns its existing ID. This takes a lock, so call it
+// during setup (e.g. the first time a Filter sees its ParameterManager),
+// not on every block from the audio thread.
+func (pm *ParameterManager) RegisterParam(name string) ParamID {
+	pm.idsMu.Lock()
+	defer pm.idsMu.Unlock()
+
+	if id, exists := pm.ids[name]; exists {
+		return id
+	}
+
+	id := ParamID(len(pm.dense))
+	pm.ids[name] = id
+
+	if value, exists := pm.Get(name); exists {
+		pm.dense = append(pm.dense, value)
+		pm.has = append(pm.has, true)
+	} else {
+		pm.dense = append(pm.dense, 0)
+		pm.has = append(pm.has, false)
+	}
+	return id
+}
+
+// GetByID reads a registered parameter's last-drained value with no lock
+// and no allocation, mirroring Get's (value, exists) shape. It only
+// reflects updates applied by the most recent DrainUpdates call, so it's
+// meant to be called from the audio thread after processBlock has drained
+// the ring for the current block.
+func (pm *ParameterManager) GetByID(id ParamID) (float64, bool) {
+	if int(id) < 0 || int(id) >= len(pm.dense) {
+		return 0, false
+	}
+	return pm.dense[id], pm.has[id]
+}
+
+// DrainUpdates applies every Set call queued since the last call into the
+// dense slice GetByID reads from. Call this once per block, from the audio
+// thread, before any GetByID reads for that block.
+func (pm *ParameterManager) DrainUpdates() {
+	pm.ring.drain(func(id ParamID, value float64) {
+		if int(id) < len(pm.dense) {
+			pm.dense[id] = value
+			pm.has[id] = true
+		}
+	})
+}
+
+// SetModulatedByID writes a modulated value straight into the dense,
+// audio-thread-private slice GetByID reads from, with no lock. It's the
+// modulation-thread counterpart of Set/DrainUpdates: ModMatrix.Tick runs on
+// the audio thread itself (from processBlock), so routing its writes
+// through Set's pm.mu.Lock() would take a full mutex on every block the
+// moment a single route exists. id must already be registered via
+// RegisterParam; an unregistered id is a no-op.
+func (pm *ParameterManager) SetModulatedByID(id ParamID, value float64) {
+	if int(id) < 0 || int(id) >= len(pm.dense) {
+		return
+	}
+	pm.dense[id] = value
+	pm.has[id] = true
+}
+
 // RegisterAtomic creates an atomic parameter for lock-free access
 func (pm *ParameterManager) RegisterAtomic(key string, initialValue float64) {
 	pm.mu.Lock()
@@ -0,0 +1,141 @@
+package audio
+
+import "math"
+
+// Resampler converts a stream between sample rates using a polyphase FIR
+// filter built from a windowed-sinc lowpass prototype. For a conversion
+// ratio L/M (in lowest terms), the prototype is split into L phase
+// sub-filters of length taps; each output sample is the dot product of
+// the phase currently selected with the input history. After every output
+// sample the phase advances by M, and each time it passes L it wraps
+// (mod L) and one new input sample is pulled into the history.
+type Resampler struct {
+	taps int
+	l, m int // output rate / input rate, reduced to lowest terms
+
+	phases [][]float64 // L phase sub-filters, each `taps` long
+
+	history []float64 // ring buffer of the last `taps` input samples
+	histPos int
+	phase   int // 0..l-1
+}
+
+// NewResampler builds a polyphase resampler converting from inRate to
+// outRate using a Kaiser-windowed-sinc prototype filter with the given
+// number of taps per phase and window shape parameter beta (larger beta
+// trades transition width for stopband attenuation; 6-10 is typical for
+// audio).
+func NewResampler(inRate, outRate, taps int, beta float64) *Resampler {
+	l, m := reduceRatio(outRate, inRate)
+	r := &Resampler{
+		taps:    taps,
+		l:       l,
+		m:       m,
+		history: make([]float64, taps),
+	}
+	r.buildPhases(beta)
+	return r
+}
+
+func reduceRatio(a, b int) (int, int) {
+	g := gcd(a, b)
+	if g == 0 {
+		return a, b
+	}
+	return a / g, b / g
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// buildPhases constructs the windowed-sinc prototype lowpass filter (taps*l
+// samples long, cut off at the lower of the input and output Nyquist
+// frequencies, expressed relative to the L-times-upsampled rate) and slices it
+// into l polyphase sub-filters, each `taps` samples, so Process never has
+// to touch the zero-stuffed upsampled signal directly.
+func (r *Resampler) buildPhases(beta float64) {
+	n := r.taps * r.l
+	center := float64(n-1) / 2.0
+	cutoff := 1.0 / math.Max(float64(r.l), float64(r.m))
+
+	prototype := make([]float64, n)
+	for i := 0; i < n; i++ {
+		x := float64(i) - center
+		var sinc float64
+		if x == 0 {
+			sinc = 1.0
+		} else {
+			sinc = math.Sin(math.Pi*cutoff*x) / (math.Pi * cutoff * x)
+		}
+		prototype[i] = sinc * cutoff * kaiser(float64(i), float64(n-1), beta)
+	}
+
+	r.phases = make([][]float64, r.l)
+	for p := 0; p < r.l; p++ {
+		sub := make([]float64, r.taps)
+		for t := 0; t < r.taps; t++ {
+			if idx := t*r.l + p; idx < n {
+				sub[t] = prototype[idx] * float64(r.l)
+			}
+		}
+		r.phases[p] = sub
+	}
+}
+
+// kaiser evaluates the Kaiser window at sample i of an (n+1)-sample window.
+func kaiser(i, n, beta float64) float64 {
+	if n == 0 {
+		return 1.0
+	}
+	ratio := (2*i - n) / n
+	return besselI0(beta*math.Sqrt(1-ratio*ratio)) / besselI0(beta)
+}
+
+// besselI0 approximates the zeroth-order modified Bessel function of the
+// first kind via its power series, which converges quickly for the beta
+// values used in window design (typically 0-12).
+func besselI0(x float64) float64 {
+	sum := 1.0
+	term := 1.0
+	for k := 1; k < 25; k++ {
+		term *= (x / (2 * float64(k))) * (x / (2 * float64(k)))
+		sum += term
+	}
+	return sum
+}
+
+// Process resamples as much of in into out as it can, returning how many
+// input samples were consumed and how many output samples were produced.
+// The resampler keeps its filter history and phase across calls, so a
+// stream can be fed through in arbitrarily sized chunks.
+func (r *Resampler) Process(in, out []float32) (consumed, produced int) {
+	for produced < len(out) {
+		// Pull in the input samples the phase has advanced past (including
+		// any still owed from the previous call) before the next output.
+		for r.phase >= r.l {
+			if consumed >= len(in) {
+				return consumed, produced
+			}
+			r.history[r.histPos] = float64(in[consumed])
+			r.histPos = (r.histPos + 1) % len(r.history)
+			consumed++
+			r.phase -= r.l
+		}
+
+		sub := r.phases[r.phase]
+		var acc float64
+		for t := 0; t < r.taps; t++ {
+			acc += sub[t] * r.history[(r.histPos+len(r.history)-1-t)%len(r.history)]
+		}
+		out[produced] = float32(acc)
+		produced++
+
+		r.phase += r.m
+	}
+
+	return consumed, produced
+}
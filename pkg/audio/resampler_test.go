@@ -0,0 +1,34 @@
+package audio
+
+import "testing"
+
+// TestResamplerProcessWeightsNewestSampleFirst is a regression test for an
+// off-by-one in the polyphase history index: tap 0 of a phase sub-filter is
+// designed to land on the most recently pushed input sample, with tap
+// taps-1 landing on the oldest. Using a 2-tap filter with easily
+// distinguished coefficients (1000, 1), we can tell which sample each tap
+// actually multiplied.
+func TestResamplerProcessWeightsNewestSampleFirst(t *testing.T) {
+	r := &Resampler{
+		taps:    2,
+		l:       1,
+		m:       1,
+		phases:  [][]float64{{1000, 1}},
+		history: make([]float64, 2),
+	}
+
+	in := []float32{1, 2, 3}
+	out := make([]float32, 3)
+	if _, produced := r.Process(in, out); produced != 3 {
+		t.Fatalf("expected 3 produced samples, got %d", produced)
+	}
+
+	// By the time the third output sample is computed, only inputs 1 and 2
+	// have been consumed (the third is only pulled in before a fourth). Tap 0
+	// (weight 1000) must hit the newest of those, 2, and tap 1 (weight 1)
+	// the older one, 1: 1000*2 + 1*1 = 2001.
+	want := float32(1000*2 + 1*1)
+	if out[2] != want {
+		t.Fatalf("out[2] = %v, want %v (tap 0 should weight the newest sample)", out[2], want)
+	}
+}
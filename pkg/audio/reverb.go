@@ -0,0 +1,141 @@
+package audio
+
+// reverbFeedbackGain is the fixed loop gain applied to the cross-fed FDN
+// signal, independent of roomSize. roomSize only scales the delay-line
+// lengths (the size of the "room"); reusing it as the feedback multiplier
+// as well would push the loop gain above 1.0 for any roomSize > 1.0 and
+// make the FDN diverge to +Inf/NaN. Kept comfortably below unity so the
+// tail always decays.
+const reverbFeedbackGain = 0.85
+
+// Base delay-line lengths (in samples, at 44.1kHz) for the Feedback Delay
+// Network. Mutually prime so the four lines never share a common
+// resonance, which keeps the tail from sounding metallic.
+var reverbFDNBaseLengths = [4]int{1687, 1601, 2053, 2251}
+
+// Base delay lengths (in samples, at 44.1kHz) for the allpass diffusers
+// that precede the FDN and thicken the early reflections.
+var reverbDiffuserBaseLengths = [3]int{142, 107, 379}
+
+// reverbState holds the delay lines and per-line filter state for a
+// Schroeder/FDN reverb. It's allocated once (sized for the engine's
+// sample rate) and reused across Process calls.
+type reverbState struct {
+	// Allpass diffuser chain, applied to the input before the FDN.
+	diffuserBufs [][]float32
+	diffuserIdx  []int
+
+	// Feedback Delay Network: 4 parallel delay lines mixed through a
+	// normalized Hadamard matrix and fed back with damping.
+	fdnLines   [4][]float32
+	fdnBaseLen [4]float64 // base length scaled to sample rate, before roomSize
+	fdnIdx     [4]int
+	fdnLP      [4]float32 // one-pole lowpass state per feedback path
+}
+
+// newReverbState allocates delay lines sized for sampleRate, with enough
+// headroom for roomSize up to 1.5.
+func newReverbState(sampleRate int) *reverbState {
+	scale := float64(sampleRate) / 44100.0
+	const maxRoomSize = 1.5
+
+	rv := &reverbState{
+		diffuserBufs: make([][]float32, len(reverbDiffuserBaseLengths)),
+		diffuserIdx:  make([]int, len(reverbDiffuserBaseLengths)),
+	}
+
+	for i, base := range reverbDiffuserBaseLengths {
+		n := int(float64(base) * scale)
+		if n < 1 {
+			n = 1
+		}
+		rv.diffuserBufs[i] = make([]float32, n)
+	}
+
+	for i, base := range reverbFDNBaseLengths {
+		rv.fdnBaseLen[i] = float64(base) * scale
+		capacity := int(rv.fdnBaseLen[i]*maxRoomSize) + 1
+		rv.fdnLines[i] = make([]float32, capacity)
+	}
+
+	return rv
+}
+
+// hadamard4 mixes four feedback taps through a normalized 4x4 Hadamard
+// matrix, so energy is redistributed between the delay lines without
+// being amplified.
+func hadamard4(in [4]float32) [4]float32 {
+	const s = 0.5 // 1/sqrt(4), keeps the matrix orthonormal
+	return [4]float32{
+		(in[0] + in[1] + in[2] + in[3]) * s,
+		(in[0] - in[1] + in[2] - in[3]) * s,
+		(in[0] + in[1] - in[2] - in[3]) * s,
+		(in[0] - in[1] - in[2] + in[3]) * s,
+	}
+}
+
+// processReverb runs a Schroeder/FDN reverb: the input is diffused through
+// a short allpass chain, then circulated through 4 delay lines mixed by a
+// Hadamard matrix, each with its own damping lowpass in the feedback path.
+func (e *Effect) processReverb(buffer []float32) {
+	roomSize := e.Parameters["roomSize"]
+	damping := e.Parameters["damping"]
+	diffusion := e.Parameters["diffusion"]
+	width := e.Parameters["width"]
+	rv := e.reverb
+
+	// Effective FDN line lengths for this block, clamped to the
+	// pre-allocated capacity (roomSize scales them 0.5-1.5x).
+	var fdnLen [4]int
+	for i := range rv.fdnLines {
+		n := int(rv.fdnBaseLen[i] * roomSize)
+		if n < 1 {
+			n = 1
+		}
+		if n > len(rv.fdnLines[i]) {
+			n = len(rv.fdnLines[i])
+		}
+		fdnLen[i] = n
+	}
+
+	for i := range buffer {
+		x := buffer[i]
+		dry := x
+
+		// Diffuse through the allpass chain to thicken early reflections.
+		for s, buf := range rv.diffuserBufs {
+			idx := rv.diffuserIdx[s]
+			delayed := buf[idx]
+			g := float32(diffusion)
+			y := -g*x + delayed
+			buf[idx] = x + g*delayed
+			rv.diffuserIdx[s] = (idx + 1) % len(buf)
+			x = y
+		}
+
+		// Read the current output of each FDN line.
+		var outs [4]float32
+		for l := range rv.fdnLines {
+			outs[l] = rv.fdnLines[l][rv.fdnIdx[l]%fdnLen[l]]
+		}
+
+		// Cross-feed the lines through the Hadamard matrix, damp each
+		// feedback path, and write the new value into each line.
+		mixed := hadamard4(outs)
+		for l := range rv.fdnLines {
+			fb := mixed[l] * reverbFeedbackGain
+			rv.fdnLP[l] = (1-float32(damping))*fb + float32(damping)*rv.fdnLP[l]
+			rv.fdnLines[l][rv.fdnIdx[l]%fdnLen[l]] = x + rv.fdnLP[l]
+			rv.fdnIdx[l] = (rv.fdnIdx[l] + 1) % fdnLen[l]
+		}
+
+		wet := (outs[0] + outs[1] + outs[2] + outs[3]) * 0.25
+
+		// Width blends in one line's output on its own (decorrelated from
+		// the other three via its mutually-prime length), widening the
+		// apparent stereo image once this mono bus is split to stereo.
+		wet = wet*(1-float32(width)) + outs[1]*float32(width)
+
+		buffer[i] = dry*(1-float32(e.Mix)) + wet*float32(e.Mix)
+	}
+}
@@ -0,0 +1,27 @@
+package audio
+
+import "testing"
+
+// TestReverbFeedbackStaysBoundedAtMaxRoomSize is a regression test for the
+// FDN feedback gain being tied to roomSize: at roomSize's documented upper
+// bound (1.5), the loop gain must stay below 1 so an impulse decays rather
+// than blowing up.
+func TestReverbFeedbackStaysBoundedAtMaxRoomSize(t *testing.T) {
+	e := NewEffect(EffectReverb, 44100)
+	e.Parameters["roomSize"] = 1.5
+	e.Mix = 1.0
+
+	buffer := make([]float32, 64)
+	buffer[0] = 1.0 // impulse
+	for block := 0; block < 200; block++ {
+		e.processReverb(buffer)
+		for _, v := range buffer {
+			if v > 4 || v < -4 {
+				t.Fatalf("reverb output exploded at block %d: %v", block, v)
+			}
+		}
+		for i := range buffer {
+			buffer[i] = 0
+		}
+	}
+}
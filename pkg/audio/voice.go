@@ -0,0 +1,248 @@
+package audio
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+// Voice is a single synth voice: an oscillator shaped by its own ADSR
+// envelope. VoiceManager owns a fixed pool of these to provide polyphony.
+type Voice struct {
+	osc *Oscillator
+	env *Envelope
+	dt  float64 // seconds per sample, passed to env.Tick
+
+	note   int
+	active bool
+	age    int64 // set from VoiceManager's tick counter, oldest wins voice stealing
+}
+
+// noteToFrequency converts a MIDI note number to its frequency in Hz,
+// using A4 (note 69) = 440 Hz as the reference pitch.
+func noteToFrequency(note int) float64 {
+	return 440.0 * math.Pow(2.0, float64(note-69)/12.0)
+}
+
+// render generates this voice's output for the block, scales it by the
+// envelope, and accumulates it into mix. tmp is scratch space sized to
+// match mix and is overwritten on every call.
+func (v *Voice) render(tmp, mix []float32) {
+	v.osc.generateSamples(tmp)
+
+	for i := range tmp {
+		env := v.env.Tick(v.dt)
+		mix[i] += tmp[i] * float32(env)
+	}
+
+	if v.env.IsIdle() {
+		v.active = false
+	}
+}
+
+// VoiceManager allocates a fixed pool of voices and handles note
+// allocation, voice stealing, and per-block rendering.
+type VoiceManager struct {
+	voices []*Voice
+	tick   int64
+
+	// events carries NoteOn/NoteOff calls from whatever goroutine is
+	// dispatching MIDI to the audio thread. NoteOn/NoteOff only enqueue;
+	// Process drains and applies them at the top of the block, so voice
+	// allocation and Voice fields (note, active, age, osc, env) are only
+	// ever touched from the audio thread, matching the split-world design
+	// ParameterManager's ring/DrainUpdates uses for parameters.
+	events *noteEventRing
+}
+
+// DefaultVoiceCount is the number of voices allocated when the caller
+// doesn't need a specific polyphony limit.
+const DefaultVoiceCount = 16
+
+// NewVoiceManager allocates a pool of numVoices voices, each with its own
+// oscillator and ADSR envelope running at sampleRate Hz. numVoices <= 0
+// falls back to DefaultVoiceCount.
+func NewVoiceManager(numVoices, sampleRate int) *VoiceManager {
+	if numVoices <= 0 {
+		numVoices = DefaultVoiceCount
+	}
+
+	vm := &VoiceManager{
+		voices: make([]*Voice, numVoices),
+		events: &noteEventRing{},
+	}
+	for i := range vm.voices {
+		vm.voices[i] = &Voice{
+			osc: NewOscillator(OscSaw, 440.0, 0.0, sampleRate),
+			env: NewEnvelope(0.01, 0.1, 0.7, 0.3),
+			dt:  1.0 / float64(sampleRate),
+		}
+	}
+	return vm
+}
+
+// noteEventKind identifies what a queued noteEvent should do when applied.
+type noteEventKind int
+
+const (
+	noteEventOn noteEventKind = iota
+	noteEventOff
+)
+
+// noteEvent is a single NoteOn/NoteOff call queued for the audio thread to
+// apply to voice state.
+type noteEvent struct {
+	kind     noteEventKind
+	note     int
+	velocity float64
+}
+
+// noteEventRingSize is the ring buffer's capacity in events. It must be a
+// power of two. MIDI messages arrive far slower than audio blocks are
+// processed, so this comfortably absorbs a burst between two drains.
+const noteEventRingSize = 256
+
+// noteEventRing is a lock-free-on-the-consumer-side ring buffer of
+// noteEvents, mirroring paramRing in params.go: any number of
+// control-thread goroutines may call push concurrently (serialized by a
+// small mutex, since MIDI dispatch isn't real-time-critical), while drain
+// — called once per block from the audio thread — never takes a lock.
+type noteEventRing struct {
+	buf        [noteEventRingSize]noteEvent
+	head       uint32 // next slot to write
+	tail       uint32 // next slot to read
+	producerMu sync.Mutex
+}
+
+func (r *noteEventRing) push(e noteEvent) {
+	r.producerMu.Lock()
+	defer r.producerMu.Unlock()
+
+	head := atomic.LoadUint32(&r.head)
+	tail := atomic.LoadUint32(&r.tail)
+	if head-tail >= noteEventRingSize {
+		// Audio thread isn't draining (e.g. engine stopped); drop the
+		// event rather than block a control-thread goroutine.
+		return
+	}
+	r.buf[head%noteEventRingSize] = e
+	atomic.StoreUint32(&r.head, head+1)
+}
+
+// drain applies every event pushed since the last drain, in order, via
+// apply. It must only be called from the audio thread.
+func (r *noteEventRing) drain(apply func(e noteEvent)) {
+	head := atomic.LoadUint32(&r.head)
+	tail := atomic.LoadUint32(&r.tail)
+	for tail != head {
+		apply(r.buf[tail%noteEventRingSize])
+		tail++
+	}
+	atomic.StoreUint32(&r.tail, tail)
+}
+
+// NoteOn queues a voice allocation for the given MIDI note (0-127) at the
+// given velocity (0.0-1.0); it's safe to call from any goroutine (e.g. a
+// MIDI listener). The voice is actually allocated, stealing one as
+// stealVoice picks if the pool is full, when Process next drains the queue.
+func (vm *VoiceManager) NoteOn(note int, velocity float64) {
+	vm.events.push(noteEvent{kind: noteEventOn, note: note, velocity: velocity})
+}
+
+// NoteOff queues the release of every voice currently playing note; it's
+// safe to call from any goroutine. Process applies it when it next drains
+// the queue, moving matching voices' envelopes into Release. A voice stays
+// active (and audible) until its envelope finishes decaying to zero.
+func (vm *VoiceManager) NoteOff(note int) {
+	vm.events.push(noteEvent{kind: noteEventOff, note: note})
+}
+
+// applyNoteOn performs the actual voice allocation for a drained NoteOn
+// event. Must only be called from the audio thread.
+func (vm *VoiceManager) applyNoteOn(note int, velocity float64) {
+	voice := vm.findFreeVoice()
+	if voice == nil {
+		voice = vm.stealVoice()
+	}
+
+	vm.tick++
+	voice.note = note
+	voice.age = vm.tick
+	voice.active = true
+	voice.osc.SetFrequency(noteToFrequency(note))
+	voice.osc.Amplitude = velocity
+	voice.env.NoteOn()
+}
+
+// applyNoteOff performs the actual release for a drained NoteOff event.
+// Must only be called from the audio thread.
+func (vm *VoiceManager) applyNoteOff(note int) {
+	for _, v := range vm.voices {
+		if v.active && v.note == note && v.env.stage != StageRelease {
+			v.env.NoteOff()
+		}
+	}
+}
+
+// findFreeVoice returns an inactive voice, or nil if the pool is full.
+func (vm *VoiceManager) findFreeVoice() *Voice {
+	for _, v := range vm.voices {
+		if !v.active {
+			return v
+		}
+	}
+	return nil
+}
+
+// stealVoice picks a voice to reclaim when the pool is full: a voice
+// already in Release is preferred, otherwise the quietest voice, with ties
+// broken by age (oldest first).
+func (vm *VoiceManager) stealVoice() *Voice {
+	victim := vm.voices[0]
+	for _, v := range vm.voices[1:] {
+		switch {
+		case v.env.stage == StageRelease && victim.env.stage != StageRelease:
+			victim = v
+		case v.env.stage != StageRelease && victim.env.stage == StageRelease:
+			// keep victim
+		case v.env.level < victim.env.level:
+			victim = v
+		case v.env.level == victim.env.level && v.age < victim.age:
+			victim = v
+		}
+	}
+	return victim
+}
+
+// Process drains any NoteOn/NoteOff events queued since the last block and
+// applies them, then renders every active voice into mix, summing them
+// into a single polyphonic block.
+func (vm *VoiceManager) Process(mix []float32) {
+	vm.events.drain(func(e noteEvent) {
+		switch e.kind {
+		case noteEventOn:
+			vm.applyNoteOn(e.note, e.velocity)
+		case noteEventOff:
+			vm.applyNoteOff(e.note)
+		}
+	})
+
+	tmp := make([]float32, len(mix))
+	for _, v := range vm.voices {
+		if !v.active {
+			continue
+		}
+		v.render(tmp, mix)
+	}
+}
+
+// ActiveVoices returns the number of voices currently sounding.
+func (vm *VoiceManager) ActiveVoices() int {
+	count := 0
+	for _, v := range vm.voices {
+		if v.active {
+			count++
+		}
+	}
+	return count
+}
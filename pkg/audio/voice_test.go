@@ -0,0 +1,39 @@
+package audio
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestVoiceManagerConcurrentNoteEventsAndProcess exercises NoteOn/NoteOff
+// from a separate goroutine (standing in for a MIDI listener) concurrently
+// with Process (standing in for the audio callback). It doesn't assert on
+// the resulting audio, only that nothing races: run with -race.
+func TestVoiceManagerConcurrentNoteEventsAndProcess(t *testing.T) {
+	vm := NewVoiceManager(DefaultVoiceCount, 44100)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			note := 60 + i%12
+			vm.NoteOn(note, 0.8)
+			vm.NoteOff(note)
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+		mix := make([]float32, 256)
+		for i := 0; i < 200; i++ {
+			for j := range mix {
+				mix[j] = 0
+			}
+			vm.Process(mix)
+		}
+	}()
+
+	wg.Wait()
+}
@@ -0,0 +1,57 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+)
+
+// writeWAV writes channelsData (one []float32 per channel, each sample in
+// -1.0..1.0) to path as a standard 16-bit PCM RIFF/WAVE file.
+func writeWAV(path string, channelsData [][]float32, sampleRate int) error {
+	numChannels := len(channelsData)
+	if numChannels == 0 {
+		return fmt.Errorf("wav: no channel data")
+	}
+	numFrames := len(channelsData[0])
+
+	const bitsPerSample = 16
+	blockAlign := numChannels * bitsPerSample / 8
+	byteRate := sampleRate * blockAlign
+	dataSize := numFrames * blockAlign
+
+	buf := new(bytes.Buffer)
+	buf.WriteString("RIFF")
+	binary.Write(buf, binary.LittleEndian, uint32(36+dataSize))
+	buf.WriteString("WAVE")
+
+	buf.WriteString("fmt ")
+	binary.Write(buf, binary.LittleEndian, uint32(16)) // PCM fmt chunk size
+	binary.Write(buf, binary.LittleEndian, uint16(1))  // format = PCM
+	binary.Write(buf, binary.LittleEndian, uint16(numChannels))
+	binary.Write(buf, binary.LittleEndian, uint32(sampleRate))
+	binary.Write(buf, binary.LittleEndian, uint32(byteRate))
+	binary.Write(buf, binary.LittleEndian, uint16(blockAlign))
+	binary.Write(buf, binary.LittleEndian, uint16(bitsPerSample))
+
+	buf.WriteString("data")
+	binary.Write(buf, binary.LittleEndian, uint32(dataSize))
+
+	for i := 0; i < numFrames; i++ {
+		for ch := 0; ch < numChannels; ch++ {
+			sample := channelsData[ch][i]
+			if sample > 1.0 {
+				sample = 1.0
+			} else if sample < -1.0 {
+				sample = -1.0
+			}
+			binary.Write(buf, binary.LittleEndian, int16(sample*32767))
+		}
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("wav: failed to write %s: %w", path, err)
+	}
+	return nil
+}
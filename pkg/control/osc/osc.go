@@ -0,0 +1,216 @@
+// Package osc listens for Open Sound Control messages over UDP and maps
+// them onto an audio.ParameterManager, giving control surfaces like
+// TouchOSC, Lemur or SuperCollider real-time control of named engine
+// parameters.
+package osc
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/SeaOfWolf/hephaestus-forge/pkg/audio"
+)
+
+// pollInterval is how often Server checks bound, smoothed parameters for a
+// glide that just finished, so it can send a "/parameter" reply.
+const pollInterval = 50 * time.Millisecond
+
+// binding maps an incoming OSC address to a parameter key and the range
+// its normalized (0.0-1.0) float argument is scaled into.
+type binding struct {
+	paramKey string
+	min, max float64
+}
+
+// Server listens for OSC messages on a UDP address and dispatches bound
+// addresses onto a ParameterManager via Set.
+type Server struct {
+	pm   *audio.ParameterManager
+	addr string
+
+	mu       sync.RWMutex
+	bindings map[string]binding
+
+	conn *net.UDPConn
+	done chan struct{}
+
+	replyMu   sync.Mutex
+	replyAddr *net.UDPAddr
+}
+
+// NewOSCServer creates a Server that will listen on addr (e.g.
+// "0.0.0.0:9000") and apply updates to pm.
+func NewOSCServer(pm *audio.ParameterManager, addr string) *Server {
+	return &Server{
+		pm:       pm,
+		addr:     addr,
+		bindings: make(map[string]binding),
+	}
+}
+
+// Bind maps an incoming OSC address to a parameter key, normalizing the
+// message's first numeric argument from [0, 1] into [min, max].
+func (s *Server) Bind(address, paramKey string, min, max float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bindings[address] = binding{paramKey: paramKey, min: min, max: max}
+}
+
+// bindingEntry mirrors one row of the on-disk mapping file Load reads.
+type bindingEntry struct {
+	Address string  `json:"address" yaml:"address"`
+	Param   string  `json:"param" yaml:"param"`
+	Min     float64 `json:"min" yaml:"min"`
+	Max     float64 `json:"max" yaml:"max"`
+}
+
+// Load reads a YAML or JSON mapping file (selected by file extension) and
+// registers a Bind for each entry, so a control layout can be defined
+// without recompiling.
+func (s *Server) Load(configPath string) error {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return fmt.Errorf("osc: failed to read %s: %w", configPath, err)
+	}
+
+	var entries []bindingEntry
+	if strings.HasSuffix(configPath, ".json") {
+		err = json.Unmarshal(data, &entries)
+	} else {
+		err = yaml.Unmarshal(data, &entries)
+	}
+	if err != nil {
+		return fmt.Errorf("osc: failed to parse %s: %w", configPath, err)
+	}
+
+	for _, entry := range entries {
+		s.Bind(entry.Address, entry.Param, entry.Min, entry.Max)
+	}
+	return nil
+}
+
+// Start opens the UDP socket and begins dispatching incoming messages and
+// polling for completed parameter glides.
+func (s *Server) Start() error {
+	udpAddr, err := net.ResolveUDPAddr("udp", s.addr)
+	if err != nil {
+		return fmt.Errorf("osc: invalid address %s: %w", s.addr, err)
+	}
+
+	conn, err := net.ListenUDP("udp", udpAddr)
+	if err != nil {
+		return fmt.Errorf("osc: failed to listen on %s: %w", s.addr, err)
+	}
+
+	s.conn = conn
+	s.done = make(chan struct{})
+
+	go s.listen()
+	go s.pollSmoothing()
+
+	log.Printf("OSC server listening on %s", s.addr)
+	return nil
+}
+
+// Stop closes the UDP socket and stops all background goroutines.
+func (s *Server) Stop() {
+	if s.done != nil {
+		close(s.done)
+	}
+	if s.conn != nil {
+		s.conn.Close()
+	}
+}
+
+// listen reads incoming OSC messages and applies bound ones to the
+// parameter manager.
+func (s *Server) listen() {
+	buf := make([]byte, 1536)
+	for {
+		n, addr, err := s.conn.ReadFromUDP(buf)
+		if err != nil {
+			select {
+			case <-s.done:
+				return
+			default:
+				log.Printf("osc: read error: %v", err)
+				return
+			}
+		}
+
+		s.replyMu.Lock()
+		s.replyAddr = addr
+		s.replyMu.Unlock()
+
+		address, args, err := decodeMessage(buf[:n])
+		if err != nil || len(args) == 0 {
+			continue
+		}
+
+		s.mu.RLock()
+		b, bound := s.bindings[address]
+		s.mu.RUnlock()
+		if !bound {
+			continue
+		}
+
+		norm := args[0]
+		s.pm.Set(b.paramKey, b.min+norm*(b.max-b.min))
+	}
+}
+
+// pollSmoothing watches every bound parameter's smoother and, the moment
+// one finishes gliding to its target, sends a "/parameter" reply bundle so
+// bidirectional surfaces (e.g. motorized faders) stay in sync.
+func (s *Server) pollSmoothing() {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	wasActive := make(map[string]bool)
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.mu.RLock()
+			keys := make([]string, 0, len(s.bindings))
+			for _, b := range s.bindings {
+				keys = append(keys, b.paramKey)
+			}
+			s.mu.RUnlock()
+
+			for _, key := range keys {
+				active := s.pm.SmoothingActive(key)
+				if !active && wasActive[key] {
+					s.notifyParameter(key, s.pm.GetWithDefault(key, 0))
+				}
+				wasActive[key] = active
+			}
+		}
+	}
+}
+
+// notifyParameter sends a "/parameter" reply bundle with key and value to
+// the last peer that sent us a message.
+func (s *Server) notifyParameter(key string, value float64) {
+	s.replyMu.Lock()
+	target := s.replyAddr
+	s.replyMu.Unlock()
+	if target == nil || s.conn == nil {
+		return // no client has connected yet to reply to
+	}
+
+	msg := encodeParameterMessage("/parameter", key, float32(value))
+	if _, err := s.conn.WriteToUDP(encodeBundle(msg), target); err != nil {
+		log.Printf("osc: failed to send /parameter reply: %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package osc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// decodeMessage parses a single (non-bundle) OSC message and returns its
+// address pattern plus any float/int arguments, coerced to float64, since
+// every binding this package supports is a plain numeric control. Parsing
+// stops at the first argument of any other type (e.g. a string or blob);
+// arguments after it are not returned.
+func decodeMessage(data []byte) (address string, args []float64, err error) {
+	address, offset, err := readOSCString(data, 0)
+	if err != nil {
+		return "", nil, err
+	}
+	if !strings.HasPrefix(address, "/") {
+		return "", nil, fmt.Errorf("osc: not a message (missing address pattern)")
+	}
+
+	typeTags, offset, err := readOSCString(data, offset)
+	if err != nil || !strings.HasPrefix(typeTags, ",") {
+		return address, nil, nil
+	}
+
+	for _, tag := range typeTags[1:] {
+		switch tag {
+		case 'f':
+			if offset+4 > len(data) {
+				return "", nil, fmt.Errorf("osc: truncated float argument")
+			}
+			bits := binary.BigEndian.Uint32(data[offset : offset+4])
+			args = append(args, float64(math.Float32frombits(bits)))
+			offset += 4
+		case 'i':
+			if offset+4 > len(data) {
+				return "", nil, fmt.Errorf("osc: truncated int argument")
+			}
+			v := int32(binary.BigEndian.Uint32(data[offset : offset+4]))
+			args = append(args, float64(v))
+			offset += 4
+		default:
+			return address, args, nil // unsupported tag; stop parsing further args
+		}
+	}
+
+	return address, args, nil
+}
+
+// readOSCString reads a null-terminated string padded to a 4-byte
+// boundary, per the OSC 1.0 spec.
+func readOSCString(data []byte, offset int) (string, int, error) {
+	end := offset
+	for end < len(data) && data[end] != 0 {
+		end++
+	}
+	if end >= len(data) {
+		return "", 0, fmt.Errorf("osc: unterminated string")
+	}
+
+	s := string(data[offset:end])
+	next := end + 1
+	if pad := next % 4; pad != 0 {
+		next += 4 - pad
+	}
+	if next > len(data) {
+		next = len(data)
+	}
+	return s, next, nil
+}
+
+// encodeOSCString null-terminates s and pads it to a 4-byte boundary.
+func encodeOSCString(s string) []byte {
+	b := append([]byte(s), 0)
+	for len(b)%4 != 0 {
+		b = append(b, 0)
+	}
+	return b
+}
+
+// encodeParameterMessage builds an OSC message reporting a parameter's
+// current key and value, e.g. for a "/parameter" reply.
+func encodeParameterMessage(address, key string, value float32) []byte {
+	buf := new(bytes.Buffer)
+	buf.Write(encodeOSCString(address))
+	buf.Write(encodeOSCString(",sf"))
+	buf.Write(encodeOSCString(key))
+	binary.Write(buf, binary.BigEndian, value)
+	return buf.Bytes()
+}
+
+// encodeBundle wraps one or more already-encoded messages in an OSC
+// bundle with an "immediate" time tag.
+func encodeBundle(messages ...[]byte) []byte {
+	buf := new(bytes.Buffer)
+	buf.Write(encodeOSCString("#bundle"))
+	binary.Write(buf, binary.BigEndian, uint64(1)) // 1 = deliver immediately
+	for _, m := range messages {
+		binary.Write(buf, binary.BigEndian, int32(len(m)))
+		buf.Write(m)
+	}
+	return buf.Bytes()
+}
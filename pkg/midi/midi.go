@@ -0,0 +1,90 @@
+// Package midi bridges a hardware or virtual MIDI controller to the audio
+// engine: NoteOn/NoteOff messages drive a VoiceManager, and a handful of
+// standard CC numbers are mapped onto ParameterManager keys.
+package midi
+
+import (
+	"fmt"
+
+	"gitlab.com/gomidi/midi/v2"
+	_ "gitlab.com/gomidi/midi/v2/drivers/rtmididrv" // registers the default OS MIDI driver
+
+	"github.com/SeaOfWolf/hephaestus-forge/pkg/audio"
+)
+
+// Standard MIDI CC numbers mapped onto engine parameters.
+const (
+	ccFilterFrequency = 74 // CC74 (sound controller 5 / "brightness")
+	ccFilterResonance = 71 // CC71 (sound controller 2 / "timbre")
+	ccModWheel        = 1  // CC1 (mod wheel)
+)
+
+// Listener dispatches incoming MIDI messages to a VoiceManager and
+// ParameterManager.
+type Listener struct {
+	voices *audio.VoiceManager
+	params *audio.ParameterManager
+	stop   func()
+}
+
+// NewListener creates a Listener that drives voices and params.
+func NewListener(voices *audio.VoiceManager, params *audio.ParameterManager) *Listener {
+	return &Listener{voices: voices, params: params}
+}
+
+// Open opens the first available MIDI input port and begins dispatching
+// NoteOn, NoteOff and CC messages.
+func (l *Listener) Open() error {
+	ins := midi.GetInPorts()
+	if len(ins) == 0 {
+		return fmt.Errorf("midi: no input ports available")
+	}
+	in := ins[0]
+
+	stop, err := midi.ListenTo(in, l.handle)
+	if err != nil {
+		return fmt.Errorf("midi: failed to listen on %s: %w", in, err)
+	}
+	l.stop = stop
+	return nil
+}
+
+// Close stops dispatching MIDI messages and releases the input port.
+func (l *Listener) Close() {
+	if l.stop != nil {
+		l.stop()
+	}
+}
+
+func (l *Listener) handle(msg midi.Message, timestampms int32) {
+	var ch, key, vel, cc, val uint8
+
+	switch {
+	case msg.GetNoteOn(&ch, &key, &vel):
+		if vel == 0 {
+			// Many controllers send NoteOn with velocity 0 instead of NoteOff.
+			l.voices.NoteOff(int(key))
+			return
+		}
+		l.voices.NoteOn(int(key), float64(vel)/127.0)
+
+	case msg.GetNoteOff(&ch, &key, &vel):
+		l.voices.NoteOff(int(key))
+
+	case msg.GetControlChange(&ch, &cc, &val):
+		l.handleCC(cc, val)
+	}
+}
+
+func (l *Listener) handleCC(cc, val uint8) {
+	norm := float64(val) / 127.0
+
+	switch cc {
+	case ccFilterFrequency:
+		l.params.Set("filter_frequency", 200.0+norm*8000.0)
+	case ccFilterResonance:
+		l.params.Set("filter_resonance", 0.1+norm*9.9)
+	case ccModWheel:
+		l.params.Set("mod_wheel", norm)
+	}
+}